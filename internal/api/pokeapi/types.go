@@ -0,0 +1,52 @@
+// Package pokeapi holds the response types returned by the PokeAPI
+// (https://pokeapi.co/docs/v2), shared by internal/pokeclient and anything
+// that needs to unmarshal its JSON.
+package pokeapi
+
+// NamedAPIResource is the {name, url} pair PokeAPI uses throughout to
+// reference another resource without embedding its full body.
+type NamedAPIResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// NamedAPIResourceList is a paginated listing of NamedAPIResources, as
+// returned by endpoints like /location-area.
+type NamedAPIResourceList struct {
+	Count    int                `json:"count"`
+	Next     *string            `json:"next"`
+	Previous *string            `json:"previous"`
+	Results  []NamedAPIResource `json:"results"`
+}
+
+// PokemonEncounter is one entry in a LocationArea's pokemon_encounters list.
+type PokemonEncounter struct {
+	Pokemon NamedAPIResource `json:"pokemon"`
+}
+
+// LocationArea is the response from /location-area/{name}.
+type LocationArea struct {
+	Name              string             `json:"name"`
+	PokemonEncounters []PokemonEncounter `json:"pokemon_encounters"`
+}
+
+// LocationAreaEncounter is one entry in the response from
+// /pokemon/{name}/encounters.
+type LocationAreaEncounter struct {
+	LocationArea NamedAPIResource `json:"location_area"`
+}
+
+// Pokemon is the response from /pokemon/{name}.
+type Pokemon struct {
+	Name           string `json:"name"`
+	Height         int    `json:"height"`
+	Weight         int    `json:"weight"`
+	BaseExperience int    `json:"base_experience"`
+	Stats          []struct {
+		BaseStat int              `json:"base_stat"`
+		Stat     NamedAPIResource `json:"stat"`
+	} `json:"stats"`
+	Types []struct {
+		Type NamedAPIResource `json:"type"`
+	} `json:"types"`
+}
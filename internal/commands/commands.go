@@ -0,0 +1,358 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rhyspowell/pokedexcli/internal/pokebattle"
+	"github.com/rhyspowell/pokedexcli/internal/pokeclient"
+	"github.com/rhyspowell/pokedexcli/internal/poketrainer"
+)
+
+// Pagination tracks the next/previous cursors MapFunc and MapbFunc page
+// through together.
+type Pagination struct {
+	Next     *string
+	Previous *string
+}
+
+// ExitFunc saves trainer to statePath, says goodbye, and exits the
+// process.
+func ExitFunc(trainer *poketrainer.Trainer, statePath string) CommandFunc {
+	return func(args []string) error {
+		if err := trainer.SaveTo(statePath); err != nil {
+			fmt.Printf("warning: failed to save state: %v\n", err)
+		}
+		fmt.Println("Closing the Pokedex... Goodbye!")
+		os.Exit(0)
+		return nil
+	}
+}
+
+// HelpFunc prints the registry's auto-generated command listing.
+func HelpFunc(registry *Registry) CommandFunc {
+	return func(args []string) error {
+		fmt.Println("Welcome to the Pokedex!")
+		fmt.Println("Usage:")
+		fmt.Println()
+		fmt.Print(registry.Help())
+		return nil
+	}
+}
+
+// MapFunc lists the next page of location areas.
+func MapFunc(client *pokeclient.Client, pagination *Pagination) CommandFunc {
+	return func(args []string) error {
+		var url string
+		if pagination.Next != nil {
+			url = *pagination.Next
+		}
+
+		result, err := client.GetNamedAPIResourceList(url)
+		if err != nil {
+			return err
+		}
+
+		pagination.Next = result.Next
+		pagination.Previous = result.Previous
+
+		for _, area := range result.Results {
+			fmt.Println(area.Name)
+		}
+
+		return nil
+	}
+}
+
+// MapbFunc lists the previous page of location areas.
+func MapbFunc(client *pokeclient.Client, pagination *Pagination) CommandFunc {
+	return func(args []string) error {
+		if pagination.Previous == nil || *pagination.Previous == "" {
+			fmt.Println("you're on the first page")
+			return nil
+		}
+
+		result, err := client.GetNamedAPIResourceList(*pagination.Previous)
+		if err != nil {
+			return err
+		}
+
+		pagination.Next = result.Next
+		pagination.Previous = result.Previous
+
+		for _, area := range result.Results {
+			fmt.Println(area.Name)
+		}
+
+		return nil
+	}
+}
+
+// VisitFunc sets the trainer's current location area, validating it
+// exists via PokeAPI first.
+func VisitFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
+	return func(args []string) error {
+		locationAreaName := args[0]
+
+		if _, err := client.GetLocationArea(locationAreaName); err != nil {
+			if errors.Is(err, pokeclient.ErrNotFound) {
+				fmt.Printf("location area %q not found\n", locationAreaName)
+				return nil
+			}
+			return err
+		}
+
+		trainer.Visit(locationAreaName)
+		fmt.Printf("You are now in %s\n", locationAreaName)
+
+		return nil
+	}
+}
+
+// ExploreFunc lists the Pokemon found in the trainer's current location
+// area.
+func ExploreFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
+	return func(args []string) error {
+		locationAreaName := trainer.CurrentLocationAreaName()
+		if locationAreaName == "" {
+			fmt.Println("You haven't visited a location area yet. Use the visit command first.")
+			return nil
+		}
+
+		result, err := client.GetLocationArea(locationAreaName)
+		if err != nil {
+			return err
+		}
+
+		if len(result.PokemonEncounters) == 0 {
+			fmt.Printf("No Pokemon found in %s\n", locationAreaName)
+			return nil
+		}
+
+		fmt.Printf("Exploring %s...\n", locationAreaName)
+		fmt.Println("Found Pokemon:")
+		for _, encounter := range result.PokemonEncounters {
+			fmt.Printf("  - %s\n", encounter.Pokemon.Name)
+		}
+
+		return nil
+	}
+}
+
+// CatchFunc attempts to catch a Pokemon, rejecting it outright if it isn't
+// one of the encounters listed for the trainer's current location area.
+func CatchFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
+	return func(args []string) error {
+		currentArea := trainer.CurrentLocationAreaName()
+		if currentArea == "" {
+			fmt.Println("You haven't visited a location area yet. Use the visit command first.")
+			return nil
+		}
+
+		pokemonName := args[0]
+
+		areas, err := client.GetPokemonLocationAreas(pokemonName)
+		if err != nil {
+			if errors.Is(err, pokeclient.ErrNotFound) {
+				fmt.Printf("pokemon %q not found\n", pokemonName)
+				return nil
+			}
+			return err
+		}
+
+		found := false
+		for _, area := range areas {
+			if area == currentArea {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fmt.Printf("%s is not found in %s\n", pokemonName, currentArea)
+			return nil
+		}
+
+		fmt.Printf("Throwing a Pokeball at %s...\n", pokemonName)
+
+		pokemon, err := client.GetPokemon(pokemonName)
+		if err != nil {
+			return err
+		}
+
+		// Calculate catch chance based on base experience
+		// Higher base experience = harder to catch
+		// Use a threshold-based system: if random number is less than threshold / (threshold + base_exp), catch succeeds
+		threshold := 100.0
+		catchChance := threshold / (threshold + float64(pokemon.BaseExperience))
+
+		// Generate random number between 0 and 1
+		rand.Seed(time.Now().UnixNano())
+		randomValue := rand.Float64()
+
+		if randomValue < catchChance {
+			// Caught!
+			fmt.Printf("%s was caught!\n", pokemonName)
+			trainer.AddToPokedex(strings.ToLower(pokemonName), pokemon)
+			fmt.Println("You may now inspect it with the inspect command.")
+		} else {
+			// Escaped!
+			fmt.Printf("%s escaped!\n", pokemonName)
+		}
+
+		return nil
+	}
+}
+
+// BattleFunc pits a Pokemon from the trainer's Pokedex against a wild
+// Pokemon drawn from the current location area's encounters, awarding the
+// wild Pokemon to the Pokedex on victory.
+func BattleFunc(client *pokeclient.Client, trainer *poketrainer.Trainer) CommandFunc {
+	return func(args []string) error {
+		pokemonName := strings.ToLower(args[0])
+		yourPokemon, ok := trainer.GetPokemonFromPokedex(pokemonName)
+		if !ok {
+			fmt.Println("you have not caught that pokemon")
+			return nil
+		}
+
+		currentArea := trainer.CurrentLocationAreaName()
+		if currentArea == "" {
+			fmt.Println("You haven't visited a location area yet. Use the visit command first.")
+			return nil
+		}
+
+		area, err := client.GetLocationArea(currentArea)
+		if err != nil {
+			return err
+		}
+		if len(area.PokemonEncounters) == 0 {
+			fmt.Printf("No Pokemon found in %s to battle\n", currentArea)
+			return nil
+		}
+
+		wildName := area.PokemonEncounters[rand.Intn(len(area.PokemonEncounters))].Pokemon.Name
+		wildPokemon, err := client.GetPokemon(wildName)
+		if err != nil {
+			return err
+		}
+
+		you := pokebattle.FromPokemon(yourPokemon)
+		wild := pokebattle.FromPokemon(wildPokemon)
+
+		fmt.Printf("A wild %s appears!\n", wild.Name)
+		// Simulate reports the winner by name, so tell a same-species
+		// pair apart before the battle.
+		if wild.Name == you.Name {
+			wild.Name = "wild " + wild.Name
+		}
+		result := pokebattle.Simulate(you, wild)
+		for _, line := range result.Log {
+			fmt.Println(line)
+		}
+
+		switch result.Winner {
+		case you.Name:
+			fmt.Printf("%s defeated %s!\n", you.Name, wild.Name)
+			trainer.AddToPokedex(strings.ToLower(wildPokemon.Name), wildPokemon)
+			fmt.Printf("%s was added to your Pokedex.\n", wildPokemon.Name)
+		case wild.Name:
+			fmt.Printf("%s fainted! You lost the battle.\n", you.Name)
+		default:
+			fmt.Println("The battle ended in a draw.")
+		}
+
+		return nil
+	}
+}
+
+// InspectFunc prints the details of a previously caught Pokemon.
+func InspectFunc(trainer *poketrainer.Trainer) CommandFunc {
+	return func(args []string) error {
+		pokemonName := strings.ToLower(args[0])
+		pokemon, ok := trainer.GetPokemonFromPokedex(pokemonName)
+		if !ok {
+			fmt.Println("you have not caught that pokemon")
+			return nil
+		}
+
+		fmt.Printf("Name: %s\n", pokemon.Name)
+		fmt.Printf("Height: %d\n", pokemon.Height)
+		fmt.Printf("Weight: %d\n", pokemon.Weight)
+		fmt.Println("Stats:")
+		for _, stat := range pokemon.Stats {
+			fmt.Printf("  -%s: %d\n", stat.Stat.Name, stat.BaseStat)
+		}
+		fmt.Println("Types:")
+		for _, t := range pokemon.Types {
+			fmt.Printf("  - %s\n", t.Type.Name)
+		}
+
+		return nil
+	}
+}
+
+// PokedexFunc lists the names of every caught Pokemon.
+func PokedexFunc(trainer *poketrainer.Trainer) CommandFunc {
+	return func(args []string) error {
+		pokedex := trainer.PokedexSnapshot()
+		if len(pokedex) == 0 {
+			fmt.Println("Your Pokedex is empty.")
+			return nil
+		}
+
+		fmt.Println("Your Pokedex:")
+		for name := range pokedex {
+			fmt.Printf(" - %s\n", name)
+		}
+
+		return nil
+	}
+}
+
+// CacheStatsFunc prints the response cache's hit/miss/eviction counts and
+// current size.
+func CacheStatsFunc(client *pokeclient.Client) CommandFunc {
+	return func(args []string) error {
+		hits, misses, evictions, bytes := client.CacheStats()
+		fmt.Printf("hits: %d\n", hits)
+		fmt.Printf("misses: %d\n", misses)
+		fmt.Printf("evictions: %d\n", evictions)
+		fmt.Printf("bytes: %d\n", bytes)
+		return nil
+	}
+}
+
+// CacheClearFunc empties the response cache.
+func CacheClearFunc(client *pokeclient.Client) CommandFunc {
+	return func(args []string) error {
+		client.ClearCache()
+		fmt.Println("Cache cleared.")
+		return nil
+	}
+}
+
+// SaveFunc writes trainer's state to statePath.
+func SaveFunc(trainer *poketrainer.Trainer, statePath string) CommandFunc {
+	return func(args []string) error {
+		if err := trainer.SaveTo(statePath); err != nil {
+			return err
+		}
+		fmt.Printf("Saved to %s\n", statePath)
+		return nil
+	}
+}
+
+// LoadFunc replaces trainer's state with what's saved at statePath.
+func LoadFunc(trainer *poketrainer.Trainer, statePath string) CommandFunc {
+	return func(args []string) error {
+		if err := trainer.LoadFrom(statePath); err != nil {
+			return err
+		}
+		fmt.Printf("Loaded from %s\n", statePath)
+		return nil
+	}
+}
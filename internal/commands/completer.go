@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rhyspowell/pokedexcli/internal/pokeclient"
+	"github.com/rhyspowell/pokedexcli/internal/poketrainer"
+)
+
+// Completer implements readline.AutoCompleter, completing command names
+// and then their arguments contextually: visit completes against known
+// location areas, inspect against caught Pokemon, and catch against
+// Pokemon seen in the trainer's current location area.
+type Completer struct {
+	registry *Registry
+	client   *pokeclient.Client
+	trainer  *poketrainer.Trainer
+
+	mu            sync.Mutex
+	locationAreas []string
+	pokedexNames  []string
+	areaPokemon   []string
+}
+
+// NewCompleter builds a Completer and populates its initial suggestion
+// sets from registry/client/trainer's current state.
+func NewCompleter(registry *Registry, client *pokeclient.Client, trainer *poketrainer.Trainer) *Completer {
+	c := &Completer{
+		registry: registry,
+		client:   client,
+		trainer:  trainer,
+	}
+	c.Refresh()
+	return c
+}
+
+// Refresh rebuilds every suggestion set from the client's cache and the
+// trainer's current state. Call it whenever either changes - e.g. after
+// every command runs, or once a background prefetch completes.
+func (c *Completer) Refresh() {
+	snapshot := c.trainer.PokedexSnapshot()
+	pokedexNames := make([]string, 0, len(snapshot))
+	for name := range snapshot {
+		pokedexNames = append(pokedexNames, name)
+	}
+	sort.Strings(pokedexNames)
+
+	var areaPokemon []string
+	if area := c.trainer.CurrentLocationAreaName(); area != "" {
+		if result, ok := c.client.PeekLocationArea(area); ok {
+			areaPokemon = make([]string, 0, len(result.PokemonEncounters))
+			for _, encounter := range result.PokemonEncounters {
+				areaPokemon = append(areaPokemon, encounter.Pokemon.Name)
+			}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.locationAreas = c.client.KnownLocationAreas()
+	c.pokedexNames = pokedexNames
+	c.areaPokemon = areaPokemon
+}
+
+// Do implements readline.AutoCompleter.
+func (c *Completer) Do(line []rune, pos int) ([][]rune, int) {
+	text := string(line[:pos])
+	fields := strings.Fields(text)
+	onArgument := strings.HasSuffix(text, " ") || len(fields) > 1
+
+	if !onArgument {
+		prefix := ""
+		if len(fields) == 1 {
+			prefix = fields[0]
+		}
+		return completions(c.registry.Names(), prefix)
+	}
+
+	prefix := ""
+	if !strings.HasSuffix(text, " ") {
+		prefix = fields[len(fields)-1]
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch fields[0] {
+	case "visit":
+		return completions(c.locationAreas, prefix)
+	case "inspect":
+		return completions(c.pokedexNames, prefix)
+	case "catch":
+		return completions(c.areaPokemon, prefix)
+	default:
+		return nil, 0
+	}
+}
+
+// completions returns every candidate with the given prefix, each with
+// the shared prefix trimmed off, as readline's AutoCompleter expects.
+func completions(candidates []string, prefix string) ([][]rune, int) {
+	var matches [][]rune
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) {
+			matches = append(matches, []rune(candidate[len(prefix):]))
+		}
+	}
+	return matches, len(prefix)
+}
@@ -0,0 +1,111 @@
+// Package commands implements the Pokedex CLI's REPL commands: a registry
+// of named commands, and factories that build each one's CommandFunc from
+// the dependencies it needs (a pokeclient.Client, a poketrainer.Trainer,
+// ...).
+package commands
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CommandFunc is a command's callback. args excludes the command name
+// itself.
+type CommandFunc func(args []string) error
+
+// Command is a named, documented entry in a Registry. MinArgs and MaxArgs
+// bound how many arguments Run will accept before calling Callback; a
+// negative MaxArgs means no upper bound. Usage is shown alongside the
+// resulting error and should read like "visit <location-area>".
+type Command struct {
+	Name        string
+	Description string
+	Usage       string
+	MinArgs     int
+	MaxArgs     int
+	Callback    CommandFunc
+}
+
+// ErrMissingArgument and ErrTooManyArguments are wrapped by the errors
+// Run returns when a command is called with the wrong number of
+// arguments, so callers can distinguish usage errors from command
+// failures.
+var (
+	ErrMissingArgument  = errors.New("missing argument")
+	ErrTooManyArguments = errors.New("too many arguments")
+)
+
+// RequireArgs validates that args has between min and max elements
+// (inclusive). A negative max means no upper bound. Run calls this for
+// every registered command before invoking its Callback, so usage mistakes
+// are reported uniformly.
+func RequireArgs(args []string, min, max int) error {
+	if len(args) < min {
+		return fmt.Errorf("%w: expected at least %d, got %d", ErrMissingArgument, min, len(args))
+	}
+	if max >= 0 && len(args) > max {
+		return fmt.Errorf("%w: expected at most %d, got %d", ErrTooManyArguments, max, len(args))
+	}
+	return nil
+}
+
+// Registry is the set of commands the REPL dispatches to by name.
+type Registry struct {
+	commands map[string]Command
+	order    []string
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		commands: make(map[string]Command),
+	}
+}
+
+// Register adds cmd, or replaces an existing command of the same name.
+func (r *Registry) Register(cmd Command) {
+	if _, exists := r.commands[cmd.Name]; !exists {
+		r.order = append(r.order, cmd.Name)
+	}
+	r.commands[cmd.Name] = cmd
+}
+
+// Run looks up name and invokes it with args. It returns an error wrapping
+// ErrUnknownCommand if no command is registered under that name, or one
+// wrapping ErrMissingArgument/ErrTooManyArguments (annotated with cmd.Usage,
+// if set) if args doesn't satisfy the command's MinArgs/MaxArgs.
+func (r *Registry) Run(name string, args []string) error {
+	cmd, ok := r.commands[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownCommand, name)
+	}
+	if err := RequireArgs(args, cmd.MinArgs, cmd.MaxArgs); err != nil {
+		if cmd.Usage != "" {
+			return fmt.Errorf("%w (usage: %s)", err, cmd.Usage)
+		}
+		return err
+	}
+	return cmd.Callback(args)
+}
+
+// ErrUnknownCommand is wrapped by the error Run returns when asked to run a
+// name that isn't registered.
+var ErrUnknownCommand = errors.New("unknown command")
+
+// Names returns every registered command name, in registration order.
+func (r *Registry) Names() []string {
+	names := make([]string, len(r.order))
+	copy(names, r.order)
+	return names
+}
+
+// Help renders a "name: description" listing of every registered command,
+// in registration order.
+func (r *Registry) Help() string {
+	help := "Available commands:\n\n"
+	for _, name := range r.order {
+		cmd := r.commands[name]
+		help += fmt.Sprintf("%s: %s\n", cmd.Name, cmd.Description)
+	}
+	return help
+}
@@ -0,0 +1,147 @@
+// Package pokebattle simulates turn-based battles between two Pokemon
+// using their base stats and type effectiveness.
+package pokebattle
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rhyspowell/pokedexcli/internal/api/pokeapi"
+)
+
+const (
+	// level is fixed for every battle; the CLI doesn't track Pokemon
+	// levels, so there's nothing else to derive it from.
+	level = 50
+	// movePower is the fixed power of the single move each combatant
+	// uses every turn.
+	movePower = 40
+	// maxRounds bounds the battle so a matchup that deals 0 damage both
+	// ways (e.g. a Normal-type vs. a Ghost-type) can't loop forever.
+	maxRounds = 100
+)
+
+// Combatant is a Pokemon actively fighting: its types and stats, plus a
+// remaining HP total that drops as it takes damage.
+type Combatant struct {
+	Name    string
+	Types   []string
+	HP      int
+	Attack  int
+	Defense int
+	Speed   int
+}
+
+// FromPokemon builds a Combatant from a fetched Pokemon's base stats and
+// types.
+func FromPokemon(p pokeapi.Pokemon) Combatant {
+	types := make([]string, 0, len(p.Types))
+	for _, t := range p.Types {
+		types = append(types, t.Type.Name)
+	}
+
+	return Combatant{
+		Name:    p.Name,
+		Types:   types,
+		HP:      baseStat(p, "hp"),
+		Attack:  baseStat(p, "attack"),
+		Defense: baseStat(p, "defense"),
+		Speed:   baseStat(p, "speed"),
+	}
+}
+
+func baseStat(p pokeapi.Pokemon, name string) int {
+	for _, stat := range p.Stats {
+		if stat.Stat.Name == name {
+			return stat.BaseStat
+		}
+	}
+	return 0
+}
+
+func (c *Combatant) fainted() bool {
+	return c.HP <= 0
+}
+
+// Result is the outcome of a Simulate call: the round-by-round log and the
+// name of the winning Combatant, or "" if the battle timed out in a draw.
+type Result struct {
+	Log    []string
+	Winner string
+}
+
+// Simulate runs a turn-based battle between a and b for up to maxRounds
+// rounds. Each round the faster Combatant attacks first; if the other is
+// still standing, it attacks back. Simulate mutates neither a nor b -
+// copies are taken internally. If neither side has fainted once maxRounds
+// is reached (e.g. a matchup that deals 0 damage both ways), the Combatant with
+// more HP remaining wins, or the battle is declared a draw if they're
+// tied.
+func Simulate(a, b Combatant) Result {
+	first, second := a, b
+	if b.Speed > a.Speed {
+		first, second = b, a
+	}
+
+	var log []string
+	for round := 0; round < maxRounds && !first.fainted() && !second.fainted(); round++ {
+		dmg := damage(first, second)
+		second.HP -= dmg
+		log = append(log, fmt.Sprintf("%s hit %s for %d damage", first.Name, second.Name, dmg))
+		if second.fainted() {
+			break
+		}
+
+		dmg = damage(second, first)
+		first.HP -= dmg
+		log = append(log, fmt.Sprintf("%s hit %s for %d damage", second.Name, first.Name, dmg))
+	}
+
+	var winner string
+	switch {
+	case first.fainted() == second.fainted():
+		// Either both started with no HP (so no round was fought), or
+		// neither fainted before maxRounds ran out - settle it on
+		// remaining HP.
+		switch {
+		case first.HP > second.HP:
+			winner = first.Name
+		case second.HP > first.HP:
+			winner = second.Name
+		default:
+			winner = ""
+		}
+	case first.fainted():
+		winner = second.Name
+	default:
+		winner = first.Name
+	}
+
+	return Result{Log: log, Winner: winner}
+}
+
+// damage computes the hit attacker lands on defender: the standard
+// level/power/attack/defense formula, then scaled by attacker's type
+// effectiveness against defender's types.
+func damage(attacker, defender Combatant) int {
+	// baseStat returns 0 for a stat PokeAPI didn't report; treat that as
+	// the minimum viable defense rather than dividing by zero.
+	defense := defender.Defense
+	if defense <= 0 {
+		defense = 1
+	}
+
+	base := math.Floor((2*float64(level)/5+2)*float64(movePower)*float64(attacker.Attack)/float64(defense)/50) + 2
+
+	moveType := "normal"
+	if len(attacker.Types) > 0 {
+		moveType = attacker.Types[0]
+	}
+	multiplier := effectivenessMultiplier(moveType, defender.Types)
+
+	dmg := int(math.Floor(base * multiplier))
+	if dmg < 0 {
+		dmg = 0
+	}
+	return dmg
+}
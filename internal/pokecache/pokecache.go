@@ -0,0 +1,161 @@
+package pokecache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type cacheEntry struct {
+	key       string
+	val       []byte
+	createdAt time.Time
+}
+
+// Options configures NewCacheWithOptions. MaxEntries and MaxBytes of 0
+// mean unlimited.
+type Options struct {
+	Interval   time.Duration
+	MaxEntries int
+	MaxBytes   int
+}
+
+// Cache is an LRU cache of byte slices keyed by string, with an optional
+// time-based reaper on top: entries are evicted once they exceed
+// Options.MaxEntries or Options.MaxBytes, and independently once they're
+// older than Options.Interval.
+type Cache struct {
+	mu         sync.Mutex
+	interval   time.Duration
+	maxEntries int
+	maxBytes   int
+	bytes      int
+	order      *list.List
+	elements   map[string]*list.Element
+
+	hits, misses, evictions uint64
+}
+
+// NewCache returns a Cache with no entry or byte limit, whose entries are
+// reaped once they're older than interval.
+func NewCache(interval time.Duration) *Cache {
+	return NewCacheWithOptions(Options{Interval: interval})
+}
+
+// NewCacheWithOptions returns a Cache configured per opts. A zero Interval
+// disables time-based reaping, relying solely on the LRU caps.
+func NewCacheWithOptions(opts Options) *Cache {
+	c := &Cache{
+		interval:   opts.Interval,
+		maxEntries: opts.MaxEntries,
+		maxBytes:   opts.MaxBytes,
+		order:      list.New(),
+		elements:   make(map[string]*list.Element),
+	}
+	if c.interval > 0 {
+		go c.reapLoop()
+	}
+	return c
+}
+
+// Add inserts or updates key, marking it most recently used, then evicts
+// the least recently used entries until both caps are satisfied.
+func (c *Cache) Add(key string, val []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.elements[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		c.bytes += len(val) - len(entry.val)
+		entry.val = val
+		entry.createdAt = time.Now()
+		c.order.MoveToFront(elem)
+	} else {
+		elem := c.order.PushFront(&cacheEntry{key: key, val: val, createdAt: time.Now()})
+		c.elements[key] = elem
+		c.bytes += len(val)
+	}
+
+	c.evictExcess()
+}
+
+// Get looks up key, marking it most recently used on a hit.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.elements[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	c.hits++
+	return elem.Value.(*cacheEntry).val, true
+}
+
+// Purge removes every entry from the cache, without affecting hit/miss/
+// eviction counters.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.elements = make(map[string]*list.Element)
+	c.bytes = 0
+}
+
+// Stats returns the cache's cumulative hit, miss, and eviction counts,
+// plus its current size in bytes.
+func (c *Cache) Stats() (hits, misses, evictions, bytes uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.hits, c.misses, c.evictions, uint64(c.bytes)
+}
+
+// evictExcess removes least-recently-used entries until both the entry
+// count and byte total are within their configured caps. Callers must
+// hold c.mu.
+func (c *Cache) evictExcess() {
+	for (c.maxEntries > 0 && len(c.elements) > c.maxEntries) || (c.maxBytes > 0 && c.bytes > c.maxBytes) {
+		oldest := c.order.Back()
+		if oldest == nil {
+			return
+		}
+		c.removeElement(oldest)
+		c.evictions++
+	}
+}
+
+// removeElement drops elem from both the LRU list and the lookup map.
+// Callers must hold c.mu.
+func (c *Cache) removeElement(elem *list.Element) {
+	entry := elem.Value.(*cacheEntry)
+	c.order.Remove(elem)
+	delete(c.elements, entry.key)
+	c.bytes -= len(entry.val)
+}
+
+func (c *Cache) reapLoop() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		c.reap(time.Now(), c.interval)
+	}
+}
+
+func (c *Cache) reap(now time.Time, maxAge time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cutoff := now.Add(-maxAge)
+	for elem := c.order.Back(); elem != nil; {
+		prev := elem.Prev()
+		if elem.Value.(*cacheEntry).createdAt.Before(cutoff) {
+			c.removeElement(elem)
+		}
+		elem = prev
+	}
+}
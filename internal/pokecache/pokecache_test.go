@@ -91,6 +91,72 @@ func TestMultipleEntries(t *testing.T) {
 	}
 }
 
+func TestNewCacheWithOptionsEvictsOverMaxEntries(t *testing.T) {
+	cache := NewCacheWithOptions(Options{MaxEntries: 2})
+
+	cache.Add("key1", []byte("value1"))
+	cache.Add("key2", []byte("value2"))
+	cache.Add("key3", []byte("value3"))
+
+	if _, ok := cache.Get("key1"); ok {
+		t.Error("expected key1 to have been evicted as least recently used")
+	}
+	if _, ok := cache.Get("key2"); !ok {
+		t.Error("expected key2 to still be cached")
+	}
+	if _, ok := cache.Get("key3"); !ok {
+		t.Error("expected key3 to still be cached")
+	}
+
+	_, _, evictions, _ := cache.Stats()
+	if evictions != 1 {
+		t.Errorf("expected 1 eviction, got %d", evictions)
+	}
+}
+
+func TestNewCacheWithOptionsEvictsOverMaxBytes(t *testing.T) {
+	cache := NewCacheWithOptions(Options{MaxBytes: 10})
+
+	cache.Add("key1", []byte("0123456789"))
+	cache.Add("key2", []byte("0123456789"))
+
+	if _, ok := cache.Get("key1"); ok {
+		t.Error("expected key1 to have been evicted to stay under the byte cap")
+	}
+
+	_, _, _, bytes := cache.Stats()
+	if bytes != 10 {
+		t.Errorf("expected 10 bytes cached, got %d", bytes)
+	}
+}
+
+func TestStatsTracksHitsAndMisses(t *testing.T) {
+	cache := NewCacheWithOptions(Options{})
+
+	cache.Add("key1", []byte("value1"))
+	cache.Get("key1")
+	cache.Get("missing")
+
+	hits, misses, _, _ := cache.Stats()
+	if hits != 1 {
+		t.Errorf("expected 1 hit, got %d", hits)
+	}
+	if misses != 1 {
+		t.Errorf("expected 1 miss, got %d", misses)
+	}
+}
+
+func TestPurge(t *testing.T) {
+	cache := NewCacheWithOptions(Options{})
+
+	cache.Add("key1", []byte("value1"))
+	cache.Purge()
+
+	if _, ok := cache.Get("key1"); ok {
+		t.Error("expected cache to be empty after Purge")
+	}
+}
+
 func TestConcurrentAccess(t *testing.T) {
 	cache := NewCache(5 * time.Second)
 	numGoroutines := 10
@@ -0,0 +1,198 @@
+// Package pokeclient provides a caching HTTP client for the PokeAPI,
+// wrapping the fetch/cache/unmarshal boilerplate needed by every endpoint
+// the CLI talks to.
+package pokeclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rhyspowell/pokedexcli/internal/api/pokeapi"
+	"github.com/rhyspowell/pokedexcli/internal/pokecache"
+)
+
+const baseURL = "https://pokeapi.co/api/v2"
+
+// Default LRU caps for the response cache. PokeAPI's location-area index
+// alone is over a thousand entries; without a cap the cache would grow
+// for as long as the REPL stays open.
+const (
+	defaultMaxCacheEntries = 1000
+	defaultMaxCacheBytes   = 10 * 1024 * 1024
+)
+
+// ErrNotFound is returned when PokeAPI responds 404, so callers can show a
+// friendly message instead of a raw HTTP error.
+var ErrNotFound = errors.New("not found")
+
+// Client is a caching PokeAPI client. It owns its own http.Client and
+// pokecache.Cache, so callers don't need to thread either through.
+type Client struct {
+	httpClient http.Client
+	cache      *pokecache.Cache
+
+	locationAreasMu sync.Mutex
+	locationAreas   map[string]struct{}
+}
+
+// NewClient returns a Client whose cache entries expire after
+// cacheInterval and whose HTTP requests time out after httpTimeout.
+func NewClient(cacheInterval, httpTimeout time.Duration) *Client {
+	return &Client{
+		httpClient: http.Client{Timeout: httpTimeout},
+		cache: pokecache.NewCacheWithOptions(pokecache.Options{
+			Interval:   cacheInterval,
+			MaxEntries: defaultMaxCacheEntries,
+			MaxBytes:   defaultMaxCacheBytes,
+		}),
+		locationAreas: make(map[string]struct{}),
+	}
+}
+
+// CacheStats returns the response cache's cumulative hit, miss, and
+// eviction counts, plus its current size in bytes.
+func (c *Client) CacheStats() (hits, misses, evictions, bytes uint64) {
+	return c.cache.Stats()
+}
+
+// ClearCache empties the response cache.
+func (c *Client) ClearCache() {
+	c.cache.Purge()
+}
+
+// get fetches url, preferring a cached response, and unmarshals the body
+// into target.
+func (c *Client) get(url string, target any) error {
+	if body, ok := c.cache.Get(url); ok {
+		fmt.Println("(using data from cache)")
+		if err := json.Unmarshal(body, target); err != nil {
+			return fmt.Errorf("error parsing cached JSON: %v", err)
+		}
+		return nil
+	}
+
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return fmt.Errorf("error fetching %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %v", err)
+	}
+
+	c.cache.Add(url, body)
+
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("error parsing JSON: %v", err)
+	}
+
+	return nil
+}
+
+// GetNamedAPIResourceList fetches a page of the /location-area listing. An
+// empty url fetches the first page. Every name seen is remembered for
+// KnownLocationAreas, since this is the only NamedAPIResourceList endpoint
+// the CLI currently paginates through.
+func (c *Client) GetNamedAPIResourceList(url string) (pokeapi.NamedAPIResourceList, error) {
+	if url == "" {
+		url = baseURL + "/location-area"
+	}
+
+	var result pokeapi.NamedAPIResourceList
+	err := c.get(url, &result)
+	if err == nil {
+		c.rememberLocationAreas(result.Results)
+	}
+	return result, err
+}
+
+func (c *Client) rememberLocationAreas(resources []pokeapi.NamedAPIResource) {
+	c.locationAreasMu.Lock()
+	defer c.locationAreasMu.Unlock()
+	for _, resource := range resources {
+		c.locationAreas[resource.Name] = struct{}{}
+	}
+}
+
+// KnownLocationAreas returns every location-area name seen across all
+// cached NamedAPIResourceList pages fetched so far, sorted alphabetically.
+func (c *Client) KnownLocationAreas() []string {
+	c.locationAreasMu.Lock()
+	defer c.locationAreasMu.Unlock()
+
+	names := make([]string, 0, len(c.locationAreas))
+	for name := range c.locationAreas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PrefetchLocationAreas fetches the full location-area index in one page,
+// populating KnownLocationAreas without waiting for the user to page
+// through map/mapb one batch at a time.
+func (c *Client) PrefetchLocationAreas() error {
+	_, err := c.GetNamedAPIResourceList(fmt.Sprintf("%s/location-area?limit=10000", baseURL))
+	return err
+}
+
+// GetLocationArea fetches a single location area by name.
+func (c *Client) GetLocationArea(name string) (pokeapi.LocationArea, error) {
+	var result pokeapi.LocationArea
+	err := c.get(fmt.Sprintf("%s/location-area/%s", baseURL, name), &result)
+	return result, err
+}
+
+// PeekLocationArea returns the location area for name if it's already in
+// the response cache, without fetching it over the network or printing the
+// cache-hit notice get does. It's for callers like the completer that want
+// to refresh off of whatever's already known without a network request on
+// every REPL prompt. The lookup goes through the cache's Get, so it counts
+// as a hit or miss in CacheStats and marks the entry most recently used.
+func (c *Client) PeekLocationArea(name string) (pokeapi.LocationArea, bool) {
+	body, ok := c.cache.Get(fmt.Sprintf("%s/location-area/%s", baseURL, name))
+	if !ok {
+		return pokeapi.LocationArea{}, false
+	}
+
+	var result pokeapi.LocationArea
+	if err := json.Unmarshal(body, &result); err != nil {
+		return pokeapi.LocationArea{}, false
+	}
+	return result, true
+}
+
+// GetPokemon fetches a single Pokemon by name.
+func (c *Client) GetPokemon(name string) (pokeapi.Pokemon, error) {
+	var result pokeapi.Pokemon
+	err := c.get(fmt.Sprintf("%s/pokemon/%s", baseURL, name), &result)
+	return result, err
+}
+
+// GetPokemonLocationAreas fetches the names of every location area a
+// Pokemon can be encountered in.
+func (c *Client) GetPokemonLocationAreas(name string) ([]string, error) {
+	var encounters []pokeapi.LocationAreaEncounter
+	if err := c.get(fmt.Sprintf("%s/pokemon/%s/encounters", baseURL, name), &encounters); err != nil {
+		return nil, err
+	}
+
+	areaNames := make([]string, 0, len(encounters))
+	for _, encounter := range encounters {
+		areaNames = append(areaNames, encounter.LocationArea.Name)
+	}
+
+	return areaNames, nil
+}
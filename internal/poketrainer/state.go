@@ -0,0 +1,93 @@
+package poketrainer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rhyspowell/pokedexcli/internal/api/pokeapi"
+)
+
+// state is the on-disk representation of a Trainer, written by SaveTo and
+// read by LoadFrom.
+type state struct {
+	Pokedex             map[string]pokeapi.Pokemon `json:"pokedex"`
+	CurrentLocationArea string                     `json:"current_location_area"`
+}
+
+// SaveTo writes the trainer's Pokedex and current location area to path as
+// JSON, creating any missing parent directories.
+func (t *Trainer) SaveTo(path string) error {
+	t.mu.Lock()
+	pokedexCopy := make(map[string]pokeapi.Pokemon, len(t.pokedex))
+	for name, pokemon := range t.pokedex {
+		pokedexCopy[name] = pokemon
+	}
+	snapshot := state{
+		Pokedex:             pokedexCopy,
+		CurrentLocationArea: t.currentLocationArea,
+	}
+	t.mu.Unlock()
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding trainer state: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error creating state directory: %v", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("error writing state file: %v", err)
+	}
+
+	return nil
+}
+
+// LoadFrom reads a trainer state previously written by SaveTo. It is not an
+// error for path to not exist yet; in that case the trainer is left
+// unchanged.
+func (t *Trainer) LoadFrom(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading state file: %v", err)
+	}
+
+	var loaded state
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return fmt.Errorf("error parsing state file: %v", err)
+	}
+
+	if loaded.Pokedex == nil {
+		loaded.Pokedex = make(map[string]pokeapi.Pokemon)
+	}
+
+	t.mu.Lock()
+	t.pokedex = loaded.Pokedex
+	t.currentLocationArea = loaded.CurrentLocationArea
+	t.mu.Unlock()
+
+	return nil
+}
+
+// DefaultStatePath returns the path state.json is saved to and loaded from
+// by default: $XDG_DATA_HOME/pokedexcli/state.json, falling back to
+// ~/.local/share/pokedexcli/state.json.
+func DefaultStatePath() (string, error) {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("error determining home directory: %v", err)
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+
+	return filepath.Join(dir, "pokedexcli", "state.json"), nil
+}
@@ -0,0 +1,71 @@
+package poketrainer
+
+import (
+	"sync"
+
+	"github.com/rhyspowell/pokedexcli/internal/api/pokeapi"
+)
+
+// Trainer holds the state that persists across commands: the Pokemon the
+// user has caught and the location area they're currently exploring from.
+// Its fields are accessed from both the REPL goroutine and the background
+// resource prefetch, so all access goes through its methods, which hold mu
+// for the duration.
+type Trainer struct {
+	mu                  sync.Mutex
+	pokedex             map[string]pokeapi.Pokemon
+	currentLocationArea string
+}
+
+// New returns a Trainer with an empty Pokedex and no current location.
+func New() *Trainer {
+	return &Trainer{
+		pokedex: make(map[string]pokeapi.Pokemon),
+	}
+}
+
+// Visit sets the trainer's current location area. Callers are expected to
+// have already validated that the area exists before calling Visit.
+func (t *Trainer) Visit(area string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.currentLocationArea = area
+}
+
+// CurrentLocationAreaName returns the name of the location area the trainer
+// is currently standing in, or "" if they haven't visited one yet.
+func (t *Trainer) CurrentLocationAreaName() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.currentLocationArea
+}
+
+// AddToPokedex records a caught Pokemon under name, exactly as given;
+// callers lowercase it first.
+func (t *Trainer) AddToPokedex(name string, pokemon pokeapi.Pokemon) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pokedex[name] = pokemon
+}
+
+// GetPokemonFromPokedex looks up a previously caught Pokemon by name.
+func (t *Trainer) GetPokemonFromPokedex(name string) (pokeapi.Pokemon, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pokemon, ok := t.pokedex[name]
+	return pokemon, ok
+}
+
+// PokedexSnapshot returns a copy of the caught-Pokemon map, safe to range
+// over while catches or battles may be adding to the real one
+// concurrently.
+func (t *Trainer) PokedexSnapshot() map[string]pokeapi.Pokemon {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snapshot := make(map[string]pokeapi.Pokemon, len(t.pokedex))
+	for name, pokemon := range t.pokedex {
+		snapshot[name] = pokemon
+	}
+	return snapshot
+}